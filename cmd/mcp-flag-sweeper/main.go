@@ -0,0 +1,135 @@
+// Command mcp-flag-sweeper runs an MCP server that exposes feature-flag
+// hygiene tools to LLM callers: sweeping a repository for stale flag checks
+// and rewriting a retired flag's checks away.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/hbg/mcp-flag-sweeper/internal/metrics"
+	"github.com/hbg/mcp-flag-sweeper/internal/remover"
+	"github.com/hbg/mcp-flag-sweeper/internal/sweeper"
+)
+
+func main() {
+	registryPath := flag.String("flags", "flags.yaml", "path to the flag lifecycle registry")
+	detectorConfigPath := flag.String("detectors", "detectors.yaml", "path to the detector selection config")
+	minAge := flag.String("min-age", "", "only report dead registrations for flags added at or before this semver")
+	metricsAddr := flag.String("metrics-addr", ":9090", "address to serve /metrics on")
+	flag.Parse()
+
+	go serveMetrics(*metricsAddr)
+
+	s := server.NewMCPServer("mcp-flag-sweeper", "0.1.0")
+	s.AddTool(sweepTool(), sweepHandler(*registryPath, *detectorConfigPath, *minAge))
+	s.AddTool(removeFlagTool(), removeFlagHandler())
+
+	if err := server.ServeStdio(s); err != nil {
+		log.Fatalf("mcp-flag-sweeper: %v", err)
+	}
+}
+
+// newToolResultError builds an error CallToolResult. mcp-go has no
+// NewToolResultError helper; it exposes IsError on the result struct instead.
+func newToolResultError(msg string) *mcp.CallToolResult {
+	result := mcp.NewToolResultText(msg)
+	result.IsError = true
+	return result
+}
+
+func serveMetrics(addr string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		log.Printf("mcp-flag-sweeper: metrics server stopped: %v", err)
+	}
+}
+
+func sweepTool() mcp.Tool {
+	return mcp.NewTool("sweep",
+		mcp.WithDescription("Scan a directory of Go source for feature-flag checks that are deprecated or retired"),
+		mcp.WithString("path", mcp.Required(), mcp.Description("directory to scan")),
+	)
+}
+
+func sweepHandler(registryPath, detectorConfigPath, minAge string) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		path, ok := req.Params.Arguments["path"].(string)
+		if !ok || path == "" {
+			return newToolResultError("path is required"), nil
+		}
+
+		reg, err := sweeper.LoadRegistry(registryPath)
+		if err != nil {
+			return newToolResultError(err.Error()), nil
+		}
+
+		detectorCfg, err := sweeper.LoadDetectorConfig(detectorConfigPath)
+		if err != nil {
+			return newToolResultError(err.Error()), nil
+		}
+		detectors, err := sweeper.SelectDetectors(detectorCfg)
+		if err != nil {
+			return newToolResultError(err.Error()), nil
+		}
+
+		start := time.Now()
+		findings, err := sweeper.Sweep(path, reg, sweeper.Options{
+			Detectors: detectors,
+			MinAge:    minAge,
+			OnReference: func(flagName, state, file string) {
+				metrics.FlagReferencesTotal.WithLabelValues(flagName, state, file).Inc()
+			},
+		})
+		metrics.ScanDuration.Observe(time.Since(start).Seconds())
+		if err != nil {
+			return newToolResultError(err.Error()), nil
+		}
+
+		out, err := json.Marshal(findings)
+		if err != nil {
+			return newToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText(string(out)), nil
+	}
+}
+
+func removeFlagTool() mcp.Tool {
+	return mcp.NewTool("remove_flag",
+		mcp.WithDescription("Inline a retired flag's taken branch and delete its dead code, returning a unified diff"),
+		mcp.WithString("path", mcp.Required(), mcp.Description("Go file to rewrite")),
+		mcp.WithString("flag", mcp.Required(), mcp.Description("flag name to remove")),
+		mcp.WithBoolean("value", mcp.Required(), mcp.Description("the value the flag should be permanently treated as")),
+	)
+}
+
+func removeFlagHandler() server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		path, ok := req.Params.Arguments["path"].(string)
+		if !ok || path == "" {
+			return newToolResultError("path is required"), nil
+		}
+		flagName, ok := req.Params.Arguments["flag"].(string)
+		if !ok || flagName == "" {
+			return newToolResultError("flag is required"), nil
+		}
+		value, ok := req.Params.Arguments["value"].(bool)
+		if !ok {
+			return newToolResultError("value is required"), nil
+		}
+
+		diff, err := remover.RemoveFlag(path, flagName, value)
+		if err != nil {
+			return newToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText(diff), nil
+	}
+}
@@ -0,0 +1,31 @@
+// Package flagregistry gives feature flags a typed, self-documenting
+// declaration, modeled on Gitaly's featureflag registry: instead of a bare
+// string constant, each flag carries the metadata a cleanup pass needs
+// (when it was added, where the rollout was tracked, what it defaults to).
+//
+// Target codebases import this package and declare flags at init time:
+//
+//	var NewCheckoutFlow = flagregistry.NewFeatureFlag(
+//		"new_checkout_flow", "1.4.0", "https://example.com/issues/123", false)
+//
+// The sweeper finds these declarations by parsing the source for calls to
+// NewFeatureFlag rather than by running the target binary's init().
+package flagregistry
+
+// FeatureFlag is the metadata recorded for a single flag declaration.
+type FeatureFlag struct {
+	Name            string
+	VersionAdded    string
+	RolloutIssueURL string
+	DefaultEnabled  bool
+}
+
+// NewFeatureFlag declares a feature flag with its rollout metadata.
+func NewFeatureFlag(name, versionAdded, rolloutIssueURL string, defaultEnabled bool) FeatureFlag {
+	return FeatureFlag{
+		Name:            name,
+		VersionAdded:    versionAdded,
+		RolloutIssueURL: rolloutIssueURL,
+		DefaultEnabled:  defaultEnabled,
+	}
+}
@@ -0,0 +1,34 @@
+// Package metrics exposes Prometheus counters for flag observations across
+// scanned corpora, following Gitaly's pattern of promauto-registered flag
+// counters: operators watching these over repeated CI scans can tell which
+// flags are proliferating versus shrinking.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// FlagReferencesTotal counts every flag check a sweep observes, labeled by
+// the flag's lifecycle state so dashboards can separate healthy checks
+// from ones that should be cleaned up.
+var FlagReferencesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "flag_sweeper_flag_references_total",
+	Help: "Total number of feature-flag checks observed by a sweep, by flag, lifecycle state, and file.",
+}, []string{"flag", "state", "file"})
+
+// ScanDuration records how long each sweep took, for spotting scans that
+// are getting slower as a corpus grows.
+var ScanDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+	Name:    "flag_sweeper_scan_duration_seconds",
+	Help:    "Time taken to sweep a directory tree.",
+	Buckets: prometheus.DefBuckets,
+})
+
+// Handler returns the HTTP handler to serve at /metrics.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
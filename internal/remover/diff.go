@@ -0,0 +1,22 @@
+package remover
+
+import (
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// unifiedDiff renders a git-style unified diff between before and after,
+// both belonging to path.
+func unifiedDiff(path string, before, after []byte) string {
+	diff := difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(before)),
+		B:        difflib.SplitLines(string(after)),
+		FromFile: path,
+		ToFile:   path,
+		Context:  3,
+	}
+	text, err := difflib.GetUnifiedDiffString(diff)
+	if err != nil {
+		return ""
+	}
+	return text
+}
@@ -0,0 +1,49 @@
+// Package remover rewrites Go source to eliminate a retired feature flag:
+// it inlines the taken branch of every isFeatureEnabled check for that
+// flag, drops the flag's constant declaration, and prunes any helper
+// functions that become unreachable as a result.
+package remover
+
+import (
+	"bytes"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+
+	"github.com/dave/dst/decorator"
+)
+
+// RemoveFlag rewrites the file at path to behave as if isFeatureEnabled(flag)
+// always returned value, returning a unified diff of the change. It refuses
+// to touch a file that doesn't parse.
+func RemoveFlag(path, flag string, value bool) (string, error) {
+	original, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read %s: %w", path, err)
+	}
+
+	// A syntax check with the standard parser first: dst will happily
+	// round-trip odd input, but we want to refuse files with unresolved
+	// compile errors rather than silently produce something worse.
+	if _, err := parser.ParseFile(token.NewFileSet(), path, original, parser.AllErrors); err != nil {
+		return "", fmt.Errorf("refusing to modify %s: it has unresolved compile errors: %w", path, err)
+	}
+
+	file, err := decorator.Parse(original)
+	if err != nil {
+		return "", fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	removeFlagFromFile(file, flag, value)
+
+	var buf bytes.Buffer
+	if err := decorator.Fprint(&buf, file); err != nil {
+		return "", fmt.Errorf("render %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return "", fmt.Errorf("write %s: %w", path, err)
+	}
+
+	return unifiedDiff(path, original, buf.Bytes()), nil
+}
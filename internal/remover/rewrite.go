@@ -0,0 +1,308 @@
+package remover
+
+import (
+	"go/token"
+	"strconv"
+
+	"github.com/dave/dst"
+)
+
+// removeFlagFromFile inlines every isFeatureEnabled(flag) check in file to
+// value, drops the flag's constant declaration (if any), and removes any
+// unexported helper function left with no remaining caller.
+func removeFlagFromFile(file *dst.File, flag string, value bool) {
+	constName := findConstName(file, flag)
+
+	// Remember which functions were called before the rewrite, so that only
+	// helpers left uncalled by it are pruned below.
+	calledBefore := make(map[string]bool)
+	dst.Inspect(file, func(n dst.Node) bool {
+		if call, ok := n.(*dst.CallExpr); ok {
+			if ident, ok := call.Fun.(*dst.Ident); ok {
+				calledBefore[ident.Name] = true
+			}
+		}
+		return true
+	})
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*dst.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		fn.Body.List = rewriteStmts(fn.Body.List, flag, constName, value)
+	}
+
+	// Function literals (goroutines, deferred closures, callbacks) aren't
+	// reached by the walk above, which only recurses into statement lists
+	// it already owns; find every one in the file and rewrite its body too.
+	dst.Inspect(file, func(n dst.Node) bool {
+		lit, ok := n.(*dst.FuncLit)
+		if !ok || lit.Body == nil {
+			return true
+		}
+		lit.Body.List = rewriteStmts(lit.Body.List, flag, constName, value)
+		return true
+	})
+
+	// Only drop the const if rewriting actually resolved every check of
+	// it; otherwise a remaining isFeatureEnabled(flag) call would be left
+	// referencing an identifier that no longer exists.
+	if !stillChecksFlag(file, flag, constName) {
+		removeConstDecl(file, constName)
+	}
+	removeUnreachableFuncs(file, calledBefore)
+}
+
+// stillChecksFlag reports whether file contains any remaining
+// isFeatureEnabled check for flag, e.g. one nested somewhere rewriteStmts
+// doesn't walk.
+func stillChecksFlag(file *dst.File, flag, constName string) bool {
+	found := false
+	dst.Inspect(file, func(n dst.Node) bool {
+		if found {
+			return false
+		}
+		call, ok := n.(*dst.CallExpr)
+		if ok && isFlagCheck(call, flag, constName) {
+			found = true
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// findConstName returns the identifier of a top-level
+// `const Name = "flag"` declaration, if one declares this flag's value.
+func findConstName(file *dst.File, flag string) string {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*dst.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vspec, ok := spec.(*dst.ValueSpec)
+			if !ok {
+				continue
+			}
+			for i, name := range vspec.Names {
+				if i >= len(vspec.Values) {
+					continue
+				}
+				if v, ok := stringLitValue(vspec.Values[i]); ok && v == flag {
+					return name.Name
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// removeConstDecl deletes the ValueSpec (and its enclosing GenDecl, if it
+// was the only spec) declaring constName. Only a spec declaring that single
+// name is deleted; a spec that declares several names is left in place.
+func removeConstDecl(file *dst.File, constName string) {
+	if constName == "" {
+		return
+	}
+
+	var decls []dst.Decl
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*dst.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			decls = append(decls, decl)
+			continue
+		}
+
+		var specs []dst.Spec
+		for _, spec := range gen.Specs {
+			vspec, ok := spec.(*dst.ValueSpec)
+			if !ok || len(vspec.Names) != 1 || vspec.Names[0].Name != constName {
+				specs = append(specs, spec)
+				continue
+			}
+		}
+		if len(specs) == 0 {
+			continue
+		}
+		gen.Specs = specs
+		decls = append(decls, gen)
+	}
+	file.Decls = decls
+}
+
+// rewriteStmts processes a statement list, inlining every isFeatureEnabled
+// check for flag to value and recursing into whichever branch survives so
+// nested checks of the same flag are resolved too.
+func rewriteStmts(stmts []dst.Stmt, flag, constName string, value bool) []dst.Stmt {
+	var out []dst.Stmt
+	for _, stmt := range stmts {
+		out = append(out, rewriteStmt(stmt, flag, constName, value)...)
+	}
+	return out
+}
+
+func rewriteStmt(stmt dst.Stmt, flag, constName string, value bool) []dst.Stmt {
+	switch s := stmt.(type) {
+	case *dst.IfStmt:
+		return rewriteIfStmt(s, flag, constName, value)
+	case *dst.BlockStmt:
+		s.List = rewriteStmts(s.List, flag, constName, value)
+		return []dst.Stmt{s}
+	case *dst.ForStmt:
+		if s.Body != nil {
+			s.Body.List = rewriteStmts(s.Body.List, flag, constName, value)
+		}
+		return []dst.Stmt{s}
+	case *dst.RangeStmt:
+		if s.Body != nil {
+			s.Body.List = rewriteStmts(s.Body.List, flag, constName, value)
+		}
+		return []dst.Stmt{s}
+	case *dst.SwitchStmt:
+		if s.Body != nil {
+			s.Body.List = rewriteStmts(s.Body.List, flag, constName, value)
+		}
+		return []dst.Stmt{s}
+	case *dst.TypeSwitchStmt:
+		if s.Body != nil {
+			s.Body.List = rewriteStmts(s.Body.List, flag, constName, value)
+		}
+		return []dst.Stmt{s}
+	case *dst.SelectStmt:
+		if s.Body != nil {
+			s.Body.List = rewriteStmts(s.Body.List, flag, constName, value)
+		}
+		return []dst.Stmt{s}
+	case *dst.CaseClause:
+		s.Body = rewriteStmts(s.Body, flag, constName, value)
+		return []dst.Stmt{s}
+	case *dst.CommClause:
+		s.Body = rewriteStmts(s.Body, flag, constName, value)
+		return []dst.Stmt{s}
+	case *dst.LabeledStmt:
+		rewritten := rewriteStmt(s.Stmt, flag, constName, value)
+		if len(rewritten) == 1 {
+			s.Stmt = rewritten[0]
+			return []dst.Stmt{s}
+		}
+		// The labeled statement resolved away entirely (or to more than
+		// one statement); a label can only wrap a single statement, so
+		// fold the rest into a block under it.
+		s.Stmt = &dst.BlockStmt{List: rewritten}
+		return []dst.Stmt{s}
+	default:
+		return []dst.Stmt{stmt}
+	}
+}
+
+func rewriteIfStmt(ifStmt *dst.IfStmt, flag, constName string, value bool) []dst.Stmt {
+	if isFlagCheck(ifStmt.Cond, flag, constName) {
+		var kept []dst.Stmt
+		if value {
+			kept = ifStmt.Body.List
+		} else if elseBlock, ok := ifStmt.Else.(*dst.BlockStmt); ok {
+			kept = elseBlock.List
+		}
+		rewritten := rewriteStmts(kept, flag, constName, value)
+		// The Init clause runs regardless of which branch the flag takes,
+		// so it has to survive the inline even though the cond itself is
+		// being deleted.
+		if ifStmt.Init != nil {
+			rewritten = append([]dst.Stmt{ifStmt.Init}, rewritten...)
+		}
+		return rewritten
+	}
+
+	ifStmt.Body.List = rewriteStmts(ifStmt.Body.List, flag, constName, value)
+	switch els := ifStmt.Else.(type) {
+	case *dst.BlockStmt:
+		els.List = rewriteStmts(els.List, flag, constName, value)
+	case *dst.IfStmt:
+		rewritten := rewriteStmt(els, flag, constName, value)
+		if len(rewritten) == 1 {
+			if nested, ok := rewritten[0].(*dst.IfStmt); ok {
+				ifStmt.Else = nested
+				break
+			}
+		}
+		// The else-if resolved away entirely (or to more than one
+		// statement); fold it into a block so it stays valid as an else.
+		ifStmt.Else = &dst.BlockStmt{List: rewritten}
+	}
+	return []dst.Stmt{ifStmt}
+}
+
+// isFlagCheck reports whether cond is `isFeatureEnabled(flag)`, matching
+// either a string literal argument or a reference to the flag's constant.
+func isFlagCheck(cond dst.Expr, flag, constName string) bool {
+	call, ok := cond.(*dst.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return false
+	}
+	fn, ok := call.Fun.(*dst.Ident)
+	if !ok || fn.Name != "isFeatureEnabled" {
+		return false
+	}
+
+	if v, ok := stringLitValue(call.Args[0]); ok {
+		return v == flag
+	}
+	if ident, ok := call.Args[0].(*dst.Ident); ok {
+		return constName != "" && ident.Name == constName
+	}
+	return false
+}
+
+// removeUnreachableFuncs drops unexported top-level functions no longer
+// called from anywhere in the file, such as a checkout path's now-dead
+// legacy branch implementation. Functions absent from calledBefore (never
+// called in the file before the rewrite) are kept. It repeats until a pass
+// removes nothing, so a chain of now-dead helpers is pruned all the way down.
+func removeUnreachableFuncs(file *dst.File, calledBefore map[string]bool) {
+	for {
+		called := make(map[string]bool)
+		dst.Inspect(file, func(n dst.Node) bool {
+			call, ok := n.(*dst.CallExpr)
+			if !ok {
+				return true
+			}
+			if ident, ok := call.Fun.(*dst.Ident); ok {
+				called[ident.Name] = true
+			}
+			return true
+		})
+
+		var decls []dst.Decl
+		removed := false
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*dst.FuncDecl)
+			if !ok || fn.Recv != nil || fn.Name.IsExported() || fn.Name.Name == "main" || fn.Name.Name == "init" {
+				decls = append(decls, decl)
+				continue
+			}
+			if called[fn.Name.Name] || !calledBefore[fn.Name.Name] {
+				decls = append(decls, decl)
+				continue
+			}
+			removed = true
+		}
+		file.Decls = decls
+		if !removed {
+			return
+		}
+	}
+}
+
+func stringLitValue(e dst.Expr) (string, bool) {
+	lit, ok := e.(*dst.BasicLit)
+	if !ok {
+		return "", false
+	}
+	v, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return "", false
+	}
+	return v, true
+}
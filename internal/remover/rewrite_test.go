@@ -0,0 +1,192 @@
+package remover
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dave/dst/decorator"
+)
+
+func rewrite(t *testing.T, src, flag string, value bool) string {
+	t.Helper()
+	file, err := decorator.Parse([]byte(src))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	removeFlagFromFile(file, flag, value)
+	var buf bytes.Buffer
+	if err := decorator.Fprint(&buf, file); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	return buf.String()
+}
+
+func TestRemoveFlagFromFileInlinesTakenBranch(t *testing.T) {
+	src := `package p
+
+func Run() { f() }
+
+func f() {
+	if isFeatureEnabled("flag") {
+		one()
+	} else {
+		two()
+	}
+}
+`
+	out := rewrite(t, src, "flag", true)
+	if strings.Contains(out, "isFeatureEnabled") {
+		t.Fatalf("expected flag check to be inlined away, got:\n%s", out)
+	}
+	if !strings.Contains(out, "one()") || strings.Contains(out, "two()") {
+		t.Fatalf("expected only the true branch to survive, got:\n%s", out)
+	}
+}
+
+func TestRemoveFlagFromFileInlinesElseBranch(t *testing.T) {
+	src := `package p
+
+func Run() { f() }
+
+func f() {
+	if isFeatureEnabled("flag") {
+		one()
+	} else {
+		two()
+	}
+}
+`
+	out := rewrite(t, src, "flag", false)
+	if strings.Contains(out, "one()") || !strings.Contains(out, "two()") {
+		t.Fatalf("expected only the false branch to survive, got:\n%s", out)
+	}
+}
+
+func TestRemoveFlagFromFileHoistsInitClause(t *testing.T) {
+	src := `package p
+
+func Run() { f() }
+
+func f() {
+	if tmp := sideEffect(); isFeatureEnabled("flag") {
+		one()
+	} else {
+		two()
+	}
+}
+`
+	out := rewrite(t, src, "flag", false)
+	if !strings.Contains(out, "tmp := sideEffect()") {
+		t.Fatalf("expected the if statement's Init clause to be hoisted rather than dropped, got:\n%s", out)
+	}
+	if !strings.Contains(out, "two()") || strings.Contains(out, "one()") {
+		t.Fatalf("expected only the false branch to survive alongside the hoisted init, got:\n%s", out)
+	}
+}
+
+func TestRemoveFlagFromFileDropsConstAndUnreachableHelper(t *testing.T) {
+	src := `package p
+
+const Flag = "flag"
+
+func Run() { f() }
+
+func f() {
+	if isFeatureEnabled(Flag) {
+		legacy()
+	}
+}
+
+func legacy() {
+	println("legacy")
+}
+`
+	out := rewrite(t, src, "flag", false)
+	if strings.Contains(out, `const Flag`) {
+		t.Fatalf("expected the flag's const declaration to be removed, got:\n%s", out)
+	}
+	if strings.Contains(out, "func legacy") {
+		t.Fatalf("expected the now-unreachable helper to be pruned, got:\n%s", out)
+	}
+}
+
+func TestRemoveFlagFromFileInlinesInsideForLoop(t *testing.T) {
+	src := `package p
+
+const NewCheckoutFlow = "flag"
+
+func Run() { f() }
+
+func f() {
+	for range items {
+		if isFeatureEnabled(NewCheckoutFlow) {
+			one()
+		} else {
+			two()
+		}
+	}
+}
+`
+	out := rewrite(t, src, "flag", false)
+	if strings.Contains(out, "isFeatureEnabled") {
+		t.Fatalf("expected the check nested in the for loop to be inlined, got:\n%s", out)
+	}
+	if strings.Contains(out, "NewCheckoutFlow") {
+		t.Fatalf("expected the const to be removed once every reference is inlined, got:\n%s", out)
+	}
+	if !strings.Contains(out, "two()") || strings.Contains(out, "one()") {
+		t.Fatalf("expected only the false branch to survive, got:\n%s", out)
+	}
+}
+
+func TestRemoveFlagFromFileInlinesInsideSwitchAndFuncLit(t *testing.T) {
+	src := `package p
+
+func Run() { f() }
+
+func f() {
+	switch x {
+	case 1:
+		if isFeatureEnabled("flag") {
+			one()
+		}
+	}
+	go func() {
+		if isFeatureEnabled("flag") {
+			three()
+		} else {
+			four()
+		}
+	}()
+}
+`
+	out := rewrite(t, src, "flag", false)
+	if strings.Contains(out, "isFeatureEnabled") {
+		t.Fatalf("expected checks inside the switch case and the goroutine's func literal to be inlined, got:\n%s", out)
+	}
+	if strings.Contains(out, "one()") || strings.Contains(out, "three()") || !strings.Contains(out, "four()") {
+		t.Fatalf("expected only the false branches to survive, got:\n%s", out)
+	}
+}
+
+func TestRemoveFlagFromFileKeepsConstWhenACheckSurvivesUnrewritten(t *testing.T) {
+	// isFeatureEnabled used as a plain expression, not an if-condition,
+	// isn't something rewriteStmt can inline away -- the safety net
+	// should refuse to delete the const it still refers to.
+	src := `package p
+
+const Flag = "flag"
+
+func Run() { f() }
+
+func f() {
+	enabled := isFeatureEnabled(Flag)
+	_ = enabled
+}
+`
+	out := rewrite(t, src, "flag", false)
+	if !strings.Contains(out, `const Flag`) {
+		t.Fatalf("expected the const to be kept since a live isFeatureEnabled(Flag) call remains, got:\n%s", out)
+	}
+}
@@ -0,0 +1,47 @@
+// Package semver implements just enough of semantic version comparison to
+// support --min-age style "is this older than X" filters; it is not a
+// general-purpose semver library.
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Compare returns -1, 0, or 1 depending on whether a is less than, equal
+// to, or greater than b. Both must be of the form MAJOR.MINOR.PATCH, with
+// MINOR and PATCH optional.
+func Compare(a, b string) (int, error) {
+	av, err := parse(a)
+	if err != nil {
+		return 0, err
+	}
+	bv, err := parse(b)
+	if err != nil {
+		return 0, err
+	}
+	for i := 0; i < 3; i++ {
+		switch {
+		case av[i] < bv[i]:
+			return -1, nil
+		case av[i] > bv[i]:
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func parse(v string) ([3]int, error) {
+	var out [3]int
+	v = strings.TrimPrefix(v, "v")
+	parts := strings.SplitN(v, ".", 3)
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return out, fmt.Errorf("invalid version %q: %w", v, err)
+		}
+		out[i] = n
+	}
+	return out, nil
+}
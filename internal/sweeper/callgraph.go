@@ -0,0 +1,190 @@
+package sweeper
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"sort"
+)
+
+// reachability is a best-effort, name-based call graph used to answer
+// "which HTTP routes or gRPC methods can reach this flag check". It
+// resolves calls by function/method name only, without type information,
+// so it can over-approximate when two types share a method name — an
+// acceptable tradeoff for a "what might be affected" signal.
+type reachability struct {
+	funcs map[string]*ast.FuncDecl
+	calls map[string]map[string]bool
+	roots map[string]string // func name -> human-readable root label
+}
+
+// buildReachability indexes every top-level func/method across files and
+// the roots reachable from HTTP and gRPC registration call sites.
+func buildReachability(files []*ast.File) *reachability {
+	r := &reachability{
+		funcs: make(map[string]*ast.FuncDecl),
+		calls: make(map[string]map[string]bool),
+		roots: make(map[string]string),
+	}
+
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			r.funcs[fn.Name.Name] = fn
+		}
+	}
+	for name, fn := range r.funcs {
+		r.calls[name] = calleeNames(fn)
+	}
+
+	grpcRootsWanted := false
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+
+			if sel.Sel.Name == "HandleFunc" && len(call.Args) == 2 {
+				if handler, ok := call.Args[1].(*ast.Ident); ok {
+					if _, known := r.funcs[handler.Name]; known {
+						r.roots[handler.Name] = "HTTP " + routeLabel(call.Args[0])
+					}
+				}
+			}
+			if isRegisterServerCall(sel.Sel.Name) {
+				grpcRootsWanted = true
+			}
+			return true
+		})
+	}
+
+	// We can't resolve which concrete type satisfies a registered gRPC
+	// service interface without full type-checking, so once any
+	// RegisterXServer call is seen, every method (a func with a receiver)
+	// is treated as a potential gRPC entry point.
+	if grpcRootsWanted {
+		for name, fn := range r.funcs {
+			if fn.Recv != nil {
+				r.roots[name] = fmt.Sprintf("gRPC %s.%s", receiverTypeName(fn), name)
+			}
+		}
+	}
+
+	return r
+}
+
+func isRegisterServerCall(name string) bool {
+	return len(name) >= len("RegisterServer") &&
+		name[:len("Register")] == "Register" &&
+		name[len(name)-len("Server"):] == "Server"
+}
+
+func receiverTypeName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	expr := fn.Recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+func routeLabel(e ast.Expr) string {
+	if v, ok := stringLit(e); ok {
+		return v
+	}
+	return "<dynamic route>"
+}
+
+// calleeNames collects the name of every function/method called from fn's
+// body, resolved by identifier only.
+func calleeNames(fn *ast.FuncDecl) map[string]bool {
+	callees := make(map[string]bool)
+	if fn.Body == nil {
+		return callees
+	}
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		switch f := call.Fun.(type) {
+		case *ast.Ident:
+			callees[f.Name] = true
+		case *ast.SelectorExpr:
+			callees[f.Sel.Name] = true
+		}
+		return true
+	})
+	return callees
+}
+
+// enclosingFunc returns the name of the top-level func/method in files
+// whose source range contains pos, or "" if pos falls outside every
+// known function (e.g. a package-level var initializer).
+func enclosingFunc(files []*ast.File, pos token.Pos) string {
+	for _, file := range files {
+		if pos < file.Pos() || pos > file.End() {
+			continue
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+			if pos >= fn.Pos() && pos <= fn.End() {
+				return fn.Name.Name
+			}
+		}
+	}
+	return ""
+}
+
+// reachedFrom returns the labels of every root in r that can reach
+// funcName, by breadth-first search over the call graph.
+func (r *reachability) reachedFrom(funcName string) []string {
+	if funcName == "" {
+		return nil
+	}
+
+	var hits []string
+	for root, label := range r.roots {
+		if root == funcName || r.canReach(root, funcName) {
+			hits = append(hits, label)
+		}
+	}
+	sort.Strings(hits)
+	return hits
+}
+
+func (r *reachability) canReach(from, to string) bool {
+	visited := map[string]bool{from: true}
+	queue := []string{from}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for callee := range r.calls[cur] {
+			if callee == to {
+				return true
+			}
+			if visited[callee] {
+				continue
+			}
+			visited[callee] = true
+			queue = append(queue, callee)
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package sweeper
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestReachedFromFindsHTTPRootsAndSortsResult(t *testing.T) {
+	fset := token.NewFileSet()
+	src := `package p
+
+import "net/http"
+
+func registerRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/z-route", zHandler)
+	mux.HandleFunc("/a-route", aHandler)
+}
+
+func zHandler(w http.ResponseWriter, r *http.Request) { target() }
+func aHandler(w http.ResponseWriter, r *http.Request) { target() }
+
+func target() {}
+`
+	file, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	graph := buildReachability([]*ast.File{file})
+	hits := graph.reachedFrom("target")
+	if len(hits) != 2 {
+		t.Fatalf("expected both routes to reach target, got %v", hits)
+	}
+	if hits[0] != "HTTP /a-route" || hits[1] != "HTTP /z-route" {
+		t.Fatalf("expected ReachedFrom sorted alphabetically, got %v", hits)
+	}
+}
+
+func TestReachedFromEmptyForUnreachableFunc(t *testing.T) {
+	fset := token.NewFileSet()
+	src := `package p
+
+func lonely() {}
+`
+	file, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	graph := buildReachability([]*ast.File{file})
+	if hits := graph.reachedFrom("lonely"); len(hits) != 0 {
+		t.Fatalf("expected no roots to reach an uncalled function, got %v", hits)
+	}
+}
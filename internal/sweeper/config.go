@@ -0,0 +1,69 @@
+package sweeper
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DetectorConfig selects which detectors run over a scanned codebase,
+// since not every target uses the same flag provider.
+type DetectorConfig struct {
+	// Enabled lists built-in detector names (see BuiltinDetectors) to run.
+	// Defaults to just "local" when empty.
+	Enabled []string `yaml:"detectors"`
+	// CustomFunctions describes additional project-specific flag-check
+	// functions to recognize alongside the built-ins.
+	CustomFunctions []CustomFunctionMatch `yaml:"customFunctions,omitempty"`
+}
+
+// LoadDetectorConfig reads a detector selection from a YAML file.
+func LoadDetectorConfig(path string) (DetectorConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return DetectorConfig{}, fmt.Errorf("load detector config: %w", err)
+	}
+	var cfg DetectorConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return DetectorConfig{}, fmt.Errorf("parse detector config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// BuiltinDetectors returns every built-in Detector keyed by its Name().
+func BuiltinDetectors() map[string]Detector {
+	return map[string]Detector{
+		"local":        LocalHelperDetector{FuncName: "isFeatureEnabled"},
+		"launchdarkly": LaunchDarklyDetector{},
+		"unleash":      UnleashDetector{},
+		"openfeature":  OpenFeatureDetector{},
+		"crowdsec":     CrowdSecDetector{},
+		"gitaly":       GitalyDetector{},
+		"envvar":       EnvVarDetector{},
+		"fromcontext":  FromContextDetector{},
+	}
+}
+
+// SelectDetectors resolves cfg into the concrete Detectors a Sweep should
+// run, defaulting to the local helper detector when cfg is empty.
+func SelectDetectors(cfg DetectorConfig) ([]Detector, error) {
+	enabled := cfg.Enabled
+	if len(enabled) == 0 {
+		enabled = []string{"local"}
+	}
+
+	builtins := BuiltinDetectors()
+	detectors := make([]Detector, 0, len(enabled)+len(cfg.CustomFunctions))
+	for _, name := range enabled {
+		d, ok := builtins[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown detector %q", name)
+		}
+		detectors = append(detectors, d)
+	}
+	for _, match := range cfg.CustomFunctions {
+		detectors = append(detectors, GenericFunctionDetector{Match: match})
+	}
+	return detectors, nil
+}
@@ -0,0 +1,32 @@
+package sweeper
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// Reference is a single flag check found by a Detector.
+type Reference struct {
+	// Detector is the name of the Detector that produced this reference.
+	Detector string
+	// Flag is the resolved flag name.
+	Flag string
+	// Pos is the call site, for turning into a file:line in Sweep.
+	Pos token.Pos
+	// Default describes the fallback taken when the flag evaluates to
+	// false, e.g. a literal default value or "else branch". Empty when a
+	// detector can't determine one.
+	Default string
+}
+
+// Detector recognizes one style of feature-flag check in a parsed Go file.
+// The sweeper runs every enabled Detector against every file and merges
+// the resulting References before cross-referencing them against the flag
+// registries.
+type Detector interface {
+	// Name identifies the detector in configuration (the detector config's
+	// `detectors` list, see DetectorConfig) and in Reference.Detector.
+	Name() string
+	// Detect returns every flag check this detector recognizes in file.
+	Detect(file *ast.File) []Reference
+}
@@ -0,0 +1,31 @@
+package sweeper
+
+import "go/ast"
+
+// FromContextDetector recognizes a project-defined `FromContext(ctx, "flag")`
+// helper, the common shape teams reach for when they don't have a typed
+// per-flag variable like Gitaly's featureflag.X.
+type FromContextDetector struct{}
+
+func (FromContextDetector) Name() string { return "fromcontext" }
+
+func (d FromContextDetector) Detect(file *ast.File) []Reference {
+	var refs []Reference
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		ident, ok := call.Fun.(*ast.Ident)
+		if !ok || ident.Name != "FromContext" || len(call.Args) != 2 {
+			return true
+		}
+		flag, ok := stringLit(call.Args[1])
+		if !ok {
+			return true
+		}
+		refs = append(refs, Reference{Detector: d.Name(), Flag: flag, Pos: call.Pos()})
+		return true
+	})
+	return refs
+}
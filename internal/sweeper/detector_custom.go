@@ -0,0 +1,60 @@
+package sweeper
+
+import "go/ast"
+
+// CustomFunctionMatch describes a project-specific flag-check function for
+// GenericFunctionDetector to recognize, e.g. a package's own
+// `flags.Enabled("x")` helper that none of the built-in detectors know
+// about.
+type CustomFunctionMatch struct {
+	// Package is the identifier the function is called through, e.g.
+	// "flags" in flags.Enabled(...). Empty matches a bare function call.
+	Package string `yaml:"package,omitempty"`
+	// Function is the called function or method name.
+	Function string `yaml:"function"`
+	// ArgIndex is the position of the string literal flag-name argument.
+	ArgIndex int `yaml:"argIndex"`
+}
+
+// GenericFunctionDetector matches calls described by a CustomFunctionMatch,
+// letting a detector config's customFunctions point the sweeper at codebases that use
+// neither the local helper nor any of the built-in providers.
+type GenericFunctionDetector struct {
+	Match CustomFunctionMatch
+}
+
+func (d GenericFunctionDetector) Name() string { return "custom:" + d.Match.Function }
+
+func (d GenericFunctionDetector) Detect(file *ast.File) []Reference {
+	if d.Match.Package == "" {
+		return detectBareCalls(file, d.Name(), d.Match.Function, d.Match.ArgIndex)
+	}
+	return detectPackageCalls(file, d.Name(), d.Match.Package, d.Match.Function, func(call *ast.CallExpr) (string, string, bool) {
+		if d.Match.ArgIndex >= len(call.Args) {
+			return "", "", false
+		}
+		flag, ok := stringLit(call.Args[d.Match.ArgIndex])
+		return flag, "", ok
+	})
+}
+
+func detectBareCalls(file *ast.File, detector, fn string, argIndex int) []Reference {
+	var refs []Reference
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		ident, ok := call.Fun.(*ast.Ident)
+		if !ok || ident.Name != fn || argIndex >= len(call.Args) {
+			return true
+		}
+		flag, ok := stringLit(call.Args[argIndex])
+		if !ok {
+			return true
+		}
+		refs = append(refs, Reference{Detector: detector, Flag: flag, Pos: call.Pos()})
+		return true
+	})
+	return refs
+}
@@ -0,0 +1,48 @@
+package sweeper
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// LocalHelperDetector recognizes calls to a project-local boolean helper
+// such as `isFeatureEnabled("flag")`, resolving a single-identifier
+// argument against the file's string constants (the pattern the original
+// chunk used throughout).
+type LocalHelperDetector struct {
+	// FuncName is the helper to match, e.g. "isFeatureEnabled".
+	FuncName string
+}
+
+func (d LocalHelperDetector) Name() string { return "local" }
+
+func (d LocalHelperDetector) Detect(file *ast.File) []Reference {
+	consts := stringConsts(file)
+
+	var refs []Reference
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		fn, ok := call.Fun.(*ast.Ident)
+		if !ok || fn.Name != d.FuncName || len(call.Args) != 1 {
+			return true
+		}
+
+		switch arg := call.Args[0].(type) {
+		case *ast.BasicLit:
+			if arg.Kind == token.STRING {
+				if v, err := unquote(arg.Value); err == nil {
+					refs = append(refs, Reference{Detector: d.Name(), Flag: v, Pos: call.Pos(), Default: "else branch"})
+				}
+			}
+		case *ast.Ident:
+			if v, ok := consts[arg.Name]; ok {
+				refs = append(refs, Reference{Detector: d.Name(), Flag: v, Pos: call.Pos(), Default: "else branch"})
+			}
+		}
+		return true
+	})
+	return refs
+}
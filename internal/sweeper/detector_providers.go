@@ -0,0 +1,229 @@
+package sweeper
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// LaunchDarklyDetector recognizes LaunchDarkly SDK variation calls, e.g.
+// client.BoolVariation("flag", ctx, false).
+type LaunchDarklyDetector struct{}
+
+func (LaunchDarklyDetector) Name() string { return "launchdarkly" }
+
+var ldVariationMethods = map[string]bool{
+	"BoolVariation":   true,
+	"StringVariation": true,
+	"IntVariation":    true,
+	"JSONVariation":   true,
+}
+
+func (d LaunchDarklyDetector) Detect(file *ast.File) []Reference {
+	return detectSelectorCalls(file, d.Name(), func(sel *ast.SelectorExpr, call *ast.CallExpr) (string, string, bool) {
+		if !ldVariationMethods[sel.Sel.Name] || len(call.Args) < 1 {
+			return "", "", false
+		}
+		flag, ok := stringLit(call.Args[0])
+		if !ok {
+			return "", "", false
+		}
+		return flag, defaultArgDescription(call.Args[len(call.Args)-1]), true
+	})
+}
+
+// UnleashDetector recognizes Unleash's package-level IsEnabled helper,
+// e.g. unleash.IsEnabled("flag").
+type UnleashDetector struct{}
+
+func (UnleashDetector) Name() string { return "unleash" }
+
+func (d UnleashDetector) Detect(file *ast.File) []Reference {
+	return detectPackageCalls(file, d.Name(), "unleash", "IsEnabled", func(call *ast.CallExpr) (string, string, bool) {
+		if len(call.Args) < 1 {
+			return "", "", false
+		}
+		flag, ok := stringLit(call.Args[0])
+		if !ok {
+			return "", "", false
+		}
+		return flag, "", true
+	})
+}
+
+// OpenFeatureDetector recognizes OpenFeature client evaluation calls, e.g.
+// client.BooleanValue(ctx, "flag", false, evalCtx).
+type OpenFeatureDetector struct{}
+
+func (OpenFeatureDetector) Name() string { return "openfeature" }
+
+var openFeatureMethods = map[string]bool{
+	"BooleanValue": true,
+	"StringValue":  true,
+	"FloatValue":   true,
+	"IntValue":     true,
+	"ObjectValue":  true,
+}
+
+func (d OpenFeatureDetector) Detect(file *ast.File) []Reference {
+	return detectSelectorCalls(file, d.Name(), func(sel *ast.SelectorExpr, call *ast.CallExpr) (string, string, bool) {
+		if !openFeatureMethods[sel.Sel.Name] || len(call.Args) < 3 {
+			return "", "", false
+		}
+		flag, ok := stringLit(call.Args[1])
+		if !ok {
+			return "", "", false
+		}
+		return flag, defaultArgDescription(call.Args[2]), true
+	})
+}
+
+// CrowdSecDetector recognizes the CrowdSec fflag style of
+// fflag.Feature("flag").IsEnabled().
+type CrowdSecDetector struct{}
+
+func (CrowdSecDetector) Name() string { return "crowdsec" }
+
+func (d CrowdSecDetector) Detect(file *ast.File) []Reference {
+	var refs []Reference
+	ast.Inspect(file, func(n ast.Node) bool {
+		outer, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		outerSel, ok := outer.Fun.(*ast.SelectorExpr)
+		if !ok || outerSel.Sel.Name != "IsEnabled" {
+			return true
+		}
+		inner, ok := outerSel.X.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		innerSel, ok := inner.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		pkg, ok := innerSel.X.(*ast.Ident)
+		if !ok || pkg.Name != "fflag" || innerSel.Sel.Name != "Feature" || len(inner.Args) != 1 {
+			return true
+		}
+		flag, ok := stringLit(inner.Args[0])
+		if !ok {
+			return true
+		}
+		refs = append(refs, Reference{Detector: d.Name(), Flag: flag, Pos: outer.Pos()})
+		return true
+	})
+	return refs
+}
+
+// GitalyDetector recognizes Gitaly's typed flag vars,
+// featureflag.SomeFlag.IsEnabled(ctx).
+type GitalyDetector struct{}
+
+func (GitalyDetector) Name() string { return "gitaly" }
+
+func (d GitalyDetector) Detect(file *ast.File) []Reference {
+	var refs []Reference
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "IsEnabled" {
+			return true
+		}
+		flagVar, ok := sel.X.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		pkg, ok := flagVar.X.(*ast.Ident)
+		if !ok || pkg.Name != "featureflag" {
+			return true
+		}
+		refs = append(refs, Reference{Detector: d.Name(), Flag: flagVar.Sel.Name, Pos: call.Pos()})
+		return true
+	})
+	return refs
+}
+
+// EnvVarDetector recognizes a generic os.Getenv("FEATURE_*") pattern,
+// treating the suffix after the FEATURE_ prefix as the flag name.
+type EnvVarDetector struct{}
+
+func (EnvVarDetector) Name() string { return "envvar" }
+
+const envVarPrefix = "FEATURE_"
+
+func (d EnvVarDetector) Detect(file *ast.File) []Reference {
+	return detectPackageCalls(file, d.Name(), "os", "Getenv", func(call *ast.CallExpr) (string, string, bool) {
+		if len(call.Args) != 1 {
+			return "", "", false
+		}
+		key, ok := stringLit(call.Args[0])
+		if !ok || !strings.HasPrefix(key, envVarPrefix) {
+			return "", "", false
+		}
+		return strings.TrimPrefix(key, envVarPrefix), "", true
+	})
+}
+
+// detectSelectorCalls walks file for any `x.method(...)` call and invokes
+// match to decide whether it's a flag reference and, if so, extract the
+// flag name and a default-value description.
+func detectSelectorCalls(file *ast.File, detector string, match func(sel *ast.SelectorExpr, call *ast.CallExpr) (flag, def string, ok bool)) []Reference {
+	var refs []Reference
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		flag, def, ok := match(sel, call)
+		if !ok {
+			return true
+		}
+		refs = append(refs, Reference{Detector: detector, Flag: flag, Pos: call.Pos(), Default: def})
+		return true
+	})
+	return refs
+}
+
+// detectPackageCalls walks file for calls to pkg.fn(...) where pkg is
+// referenced by its plain identifier (no aliasing is resolved).
+func detectPackageCalls(file *ast.File, detector, pkg, fn string, match func(call *ast.CallExpr) (flag, def string, ok bool)) []Reference {
+	return detectSelectorCalls(file, detector, func(sel *ast.SelectorExpr, call *ast.CallExpr) (string, string, bool) {
+		ident, ok := sel.X.(*ast.Ident)
+		if !ok || ident.Name != pkg || sel.Sel.Name != fn {
+			return "", "", false
+		}
+		return match(call)
+	})
+}
+
+// defaultArgDescription renders a fallback-value argument as a short
+// human-readable description. An identifier (such as true, false or a
+// named constant) renders as its name; any other non-literal renders as "".
+func defaultArgDescription(arg ast.Expr) string {
+	lit, ok := arg.(*ast.BasicLit)
+	if !ok {
+		if ident, ok := arg.(*ast.Ident); ok {
+			return ident.Name
+		}
+		return ""
+	}
+	switch lit.Kind {
+	case token.STRING:
+		v, err := unquote(lit.Value)
+		if err != nil {
+			return ""
+		}
+		return v
+	default:
+		return lit.Value
+	}
+}
@@ -0,0 +1,59 @@
+package sweeper
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func parseSrc(t *testing.T, src string) *ast.File {
+	t.Helper()
+	file, err := parser.ParseFile(token.NewFileSet(), "src.go", src, 0)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	return file
+}
+
+func TestOpenFeatureDetectorDoesNotPanicOnTwoArgSelectorCall(t *testing.T) {
+	// A local type that merely happens to have a 2-arg BooleanValue method;
+	// OpenFeatureDetector matches by name only, so this must not panic when
+	// it indexes the default-value argument.
+	file := parseSrc(t, `package p
+
+type other struct{}
+
+func (other) BooleanValue(ctx, flag string) bool { return false }
+
+func run() {
+	var o other
+	o.BooleanValue("ctx", "flag")
+}
+`)
+
+	refs := OpenFeatureDetector{}.Detect(file)
+	if len(refs) != 0 {
+		t.Fatalf("expected the under-length call to be ignored, got %+v", refs)
+	}
+}
+
+func TestOpenFeatureDetectorFindsFlagWithDefault(t *testing.T) {
+	file := parseSrc(t, `package p
+
+func run() {
+	client.BooleanValue(ctx, "new_checkout_flow", false, evalCtx)
+}
+`)
+
+	refs := OpenFeatureDetector{}.Detect(file)
+	if len(refs) != 1 {
+		t.Fatalf("expected exactly one reference, got %+v", refs)
+	}
+	if refs[0].Flag != "new_checkout_flow" {
+		t.Fatalf("expected the flag name to be resolved, got %+v", refs[0])
+	}
+	if refs[0].Default != "false" {
+		t.Fatalf("expected the default-value description to be extracted, got %+v", refs[0])
+	}
+}
@@ -0,0 +1,88 @@
+package sweeper
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/hbg/mcp-flag-sweeper/internal/flagregistry"
+)
+
+// extractRegistrations finds `flagregistry.NewFeatureFlag(...)` call sites
+// in file and resolves their literal arguments into FeatureFlag metadata.
+// It is a static, source-level stand-in for running the target binary's
+// init() functions.
+func extractRegistrations(file *ast.File) map[string]flagregistry.FeatureFlag {
+	pkgIdent := flagregistryIdent(file)
+	if pkgIdent == "" {
+		return nil
+	}
+
+	regs := make(map[string]flagregistry.FeatureFlag)
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		x, ok := sel.X.(*ast.Ident)
+		if !ok || x.Name != pkgIdent || sel.Sel.Name != "NewFeatureFlag" || len(call.Args) != 4 {
+			return true
+		}
+
+		name, ok1 := stringLit(call.Args[0])
+		versionAdded, ok2 := stringLit(call.Args[1])
+		rolloutURL, ok3 := stringLit(call.Args[2])
+		defaultEnabled, ok4 := boolLit(call.Args[3])
+		if !ok1 || !ok2 || !ok3 || !ok4 {
+			return true
+		}
+
+		regs[name] = flagregistry.NewFeatureFlag(name, versionAdded, rolloutURL, defaultEnabled)
+		return true
+	})
+	return regs
+}
+
+// flagregistryIdent returns the local identifier file uses to refer to
+// the flagregistry package, or "" if it isn't imported.
+func flagregistryIdent(file *ast.File) string {
+	for _, imp := range file.Imports {
+		path := strings.Trim(imp.Path.Value, `"`)
+		if path != "github.com/hbg/mcp-flag-sweeper/internal/flagregistry" {
+			continue
+		}
+		if imp.Name != nil {
+			return imp.Name.Name
+		}
+		return "flagregistry"
+	}
+	return ""
+}
+
+func stringLit(e ast.Expr) (string, bool) {
+	lit, ok := e.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	v, err := unquote(lit.Value)
+	return v, err == nil
+}
+
+func boolLit(e ast.Expr) (bool, bool) {
+	ident, ok := e.(*ast.Ident)
+	if !ok {
+		return false, false
+	}
+	switch ident.Name {
+	case "true":
+		return true, true
+	case "false":
+		return false, true
+	default:
+		return false, false
+	}
+}
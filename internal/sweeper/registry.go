@@ -0,0 +1,54 @@
+package sweeper
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FlagMeta is the lifecycle metadata the sweeper knows about a single flag,
+// as declared in a flags.yaml file.
+type FlagMeta struct {
+	Name              string    `yaml:"name"`
+	State             FlagState `yaml:"state"`
+	Message           string    `yaml:"message"`
+	VersionIntroduced string    `yaml:"versionIntroduced,omitempty"`
+	VersionRetired    string    `yaml:"versionRetired,omitempty"`
+}
+
+// Registry maps a flag name to its lifecycle metadata.
+type Registry map[string]FlagMeta
+
+type registryFile struct {
+	Flags []FlagMeta `yaml:"flags"`
+}
+
+// LoadRegistry reads a flags.yaml file describing the lifecycle state of
+// every known flag. Flags absent from the registry are treated as Active.
+func LoadRegistry(path string) (Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("load flag registry: %w", err)
+	}
+
+	var doc registryFile
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse flag registry %s: %w", path, err)
+	}
+
+	reg := make(Registry, len(doc.Flags))
+	for _, f := range doc.Flags {
+		reg[f.Name] = f
+	}
+	return reg, nil
+}
+
+// Lookup returns the lifecycle metadata for name, defaulting to Active when
+// the flag isn't declared in the registry.
+func (r Registry) Lookup(name string) FlagMeta {
+	if meta, ok := r[name]; ok {
+		return meta
+	}
+	return FlagMeta{Name: name, State: Active}
+}
@@ -0,0 +1,52 @@
+package sweeper
+
+import "fmt"
+
+// FlagState describes where a feature flag sits in its lifecycle.
+type FlagState int
+
+const (
+	// Active flags are still rolling out or intentionally long-lived.
+	Active FlagState = iota
+	// Deprecated flags have a replacement or known removal plan but are
+	// still referenced in code. Sweeping surfaces these as warnings.
+	Deprecated
+	// Retired flags have already shipped their final value and must not
+	// be re-enabled. Sweeping surfaces these as errors with a removal
+	// suggestion.
+	Retired
+)
+
+func (s FlagState) String() string {
+	switch s {
+	case Active:
+		return "active"
+	case Deprecated:
+		return "deprecated"
+	case Retired:
+		return "retired"
+	default:
+		return fmt.Sprintf("FlagState(%d)", int(s))
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler so FlagState round-trips
+// through YAML and JSON as its lowercase name rather than an int.
+func (s FlagState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *FlagState) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "active", "":
+		*s = Active
+	case "deprecated":
+		*s = Deprecated
+	case "retired":
+		*s = Retired
+	default:
+		return fmt.Errorf("unknown flag state %q", text)
+	}
+	return nil
+}
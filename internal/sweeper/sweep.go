@@ -0,0 +1,295 @@
+// Package sweeper scans Go source for feature-flag checks and reports on
+// their lifecycle state.
+package sweeper
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hbg/mcp-flag-sweeper/internal/flagregistry"
+	"github.com/hbg/mcp-flag-sweeper/internal/semver"
+)
+
+// Finding is one flag reference surfaced by a sweep, structured so an MCP
+// caller can act on it without re-parsing free text.
+type Finding struct {
+	Flag             string   `json:"flag"`
+	Kind             string   `json:"kind"`
+	State            string   `json:"state,omitempty"`
+	File             string   `json:"file"`
+	Line             int      `json:"line,omitempty"`
+	Message          string   `json:"message"`
+	Default          string   `json:"default,omitempty"`
+	SuggestedRemoval string   `json:"suggestedRemoval,omitempty"`
+	VersionAdded     string   `json:"versionAdded,omitempty"`
+	RolloutIssueURL  string   `json:"rolloutIssueUrl,omitempty"`
+	ReachedFrom      []string `json:"reachedFrom,omitempty"`
+}
+
+// Finding kinds.
+const (
+	KindLifecycle        = "lifecycle"        // a Deprecated/Retired flag from the YAML registry
+	KindUnregistered     = "unregistered"      // referenced but never declared via flagregistry
+	KindDeadRegistration = "dead-registration" // declared via flagregistry but never referenced
+	KindReachedFrom      = "reached-from"      // a context-scoped check, annotated with its reachable HTTP/gRPC entry points
+)
+
+// contextDetectors are the detector names whose References are traced back
+// to the HTTP routes and gRPC methods that can reach them.
+var contextDetectors = map[string]bool{
+	"gitaly":      true,
+	"fromcontext": true,
+}
+
+// Options tunes what a Sweep reports.
+type Options struct {
+	// Detectors are the flag-check detectors to run over every file.
+	// Defaults to []Detector{LocalHelperDetector{FuncName: "isFeatureEnabled"}}
+	// when nil; callers typically build this via SelectDetectors.
+	Detectors []Detector
+	// MinAge, if set, restricts dead-registration findings to flags
+	// declared at or before this semver version. Unregistered findings
+	// carry no version and are reported regardless of MinAge.
+	MinAge string
+	// OnReference, if set, is called for every flag check a detector
+	// finds, including Active ones that produce no Finding. Callers use
+	// this to feed observations into metrics without the sweeper package
+	// needing to know about Prometheus.
+	OnReference func(flag, state, file string)
+}
+
+// Sweep walks every .go file under root running opts.Detectors over each
+// one. It reports a Finding for any flag that is Deprecated or Retired
+// according to reg, any reference with no corresponding flagregistry
+// declaration, and any flagregistry declaration with no reference anywhere
+// in the tree.
+func Sweep(root string, reg Registry, opts Options) ([]Finding, error) {
+	detectors := opts.Detectors
+	if len(detectors) == 0 {
+		detectors = []Detector{LocalHelperDetector{FuncName: "isFeatureEnabled"}}
+	}
+
+	var findings []Finding
+	referenced := make(map[string]bool)
+	registrations := make(map[string]flagregistry.FeatureFlag)
+	var files []*ast.File
+	var contextRefs []contextRef
+
+	fset := token.NewFileSet()
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return fmt.Errorf("parse %s: %w", path, err)
+		}
+		files = append(files, file)
+
+		for name, reg := range extractRegistrations(file) {
+			registrations[name] = reg
+		}
+
+		for _, detector := range detectors {
+			for _, ref := range detector.Detect(file) {
+				referenced[ref.Flag] = true
+
+				meta := reg.Lookup(ref.Flag)
+				if opts.OnReference != nil {
+					opts.OnReference(ref.Flag, meta.State.String(), path)
+				}
+
+				if contextDetectors[ref.Detector] {
+					contextRefs = append(contextRefs, contextRef{flag: ref.Flag, pos: ref.Pos, file: path})
+				}
+
+				if meta.State == Active {
+					continue
+				}
+
+				pos := fset.Position(ref.Pos)
+				findings = append(findings, Finding{
+					Flag:             ref.Flag,
+					Kind:             KindLifecycle,
+					State:            meta.State.String(),
+					File:             path,
+					Line:             pos.Line,
+					Message:          findingMessage(meta),
+					Default:          ref.Default,
+					SuggestedRemoval: suggestedRemoval(meta),
+				})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// registrations isn't fully populated until every file has been walked,
+	// so a lifecycle finding can't pick up its flagregistry metadata until
+	// now -- a flag may be registered in a file visited after the one
+	// where it's checked.
+	for i := range findings {
+		if findings[i].Kind != KindLifecycle {
+			continue
+		}
+		if reg, ok := registrations[findings[i].Flag]; ok {
+			findings[i].VersionAdded = reg.VersionAdded
+			findings[i].RolloutIssueURL = reg.RolloutIssueURL
+		}
+	}
+
+	crossRef, err := crossReference(referenced, registrations, opts)
+	if err != nil {
+		return nil, err
+	}
+	findings = append(findings, crossRef...)
+
+	if len(contextRefs) > 0 {
+		graph := buildReachability(files)
+		for _, ref := range contextRefs {
+			findings = append(findings, Finding{
+				Flag:        ref.flag,
+				Kind:        KindReachedFrom,
+				File:        ref.file,
+				Line:        fset.Position(ref.pos).Line,
+				Message:     fmt.Sprintf("flag %q is read from a request-scoped context", ref.flag),
+				ReachedFrom: graph.reachedFrom(enclosingFunc(files, ref.pos)),
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+// contextRef is a flag check read from a request-scoped context, pending
+// reachability analysis once every file in the tree has been parsed.
+type contextRef struct {
+	flag string
+	pos  token.Pos
+	file string
+}
+
+// crossReference compares flags actually referenced by any detector
+// against flags declared via flagregistry.NewFeatureFlag, reporting both
+// directions of mismatch.
+func crossReference(referenced map[string]bool, registrations map[string]flagregistry.FeatureFlag, opts Options) ([]Finding, error) {
+	var findings []Finding
+
+	for name := range referenced {
+		if _, ok := registrations[name]; ok {
+			continue
+		}
+		findings = append(findings, Finding{
+			Flag:    name,
+			Kind:    KindUnregistered,
+			Message: fmt.Sprintf("flag %q is checked but never declared via flagregistry.NewFeatureFlag", name),
+		})
+	}
+
+	for name, reg := range registrations {
+		old, err := olderThanMinAge(reg.VersionAdded, opts.MinAge)
+		if err != nil {
+			return nil, err
+		}
+		if !old {
+			continue
+		}
+		if referenced[name] {
+			continue
+		}
+		findings = append(findings, Finding{
+			Flag:             name,
+			Kind:             KindDeadRegistration,
+			Message:          fmt.Sprintf("flag %q is declared via flagregistry but never referenced", name),
+			SuggestedRemoval: fmt.Sprintf("remove the flagregistry.NewFeatureFlag(%q, ...) declaration", name),
+			VersionAdded:     reg.VersionAdded,
+			RolloutIssueURL:  reg.RolloutIssueURL,
+		})
+	}
+
+	return findings, nil
+}
+
+// olderThanMinAge reports whether versionAdded is at or before minAge. An
+// empty minAge disables the filter and always reports true.
+func olderThanMinAge(versionAdded, minAge string) (bool, error) {
+	if minAge == "" {
+		return true, nil
+	}
+	if versionAdded == "" {
+		return false, nil
+	}
+	cmp, err := semver.Compare(versionAdded, minAge)
+	if err != nil {
+		return false, fmt.Errorf("compare version %q to --min-age %q: %w", versionAdded, minAge, err)
+	}
+	return cmp <= 0, nil
+}
+
+func findingMessage(meta FlagMeta) string {
+	if meta.Message != "" {
+		return meta.Message
+	}
+	switch meta.State {
+	case Deprecated:
+		return fmt.Sprintf("flag %q is deprecated and should be migrated off", meta.Name)
+	case Retired:
+		return fmt.Sprintf("flag %q is retired and can no longer be re-enabled", meta.Name)
+	default:
+		return ""
+	}
+}
+
+func suggestedRemoval(meta FlagMeta) string {
+	if meta.State != Retired {
+		return ""
+	}
+	return fmt.Sprintf("remove all isFeatureEnabled(%q) checks and their dead branch", meta.Name)
+}
+
+// stringConsts collects `const Name = "value"` declarations so call sites
+// that pass an identifier, rather than a literal, can still be resolved.
+func stringConsts(file *ast.File) map[string]string {
+	consts := make(map[string]string)
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vspec, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+			for i, name := range vspec.Names {
+				if i >= len(vspec.Values) {
+					continue
+				}
+				lit, ok := vspec.Values[i].(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					continue
+				}
+				if v, err := unquote(lit.Value); err == nil {
+					consts[name.Name] = v
+				}
+			}
+		}
+	}
+	return consts
+}
+
+func unquote(lit string) (string, error) {
+	return strconv.Unquote(lit)
+}
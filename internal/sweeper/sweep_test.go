@@ -0,0 +1,248 @@
+package sweeper
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+}
+
+func findingsByFlag(findings []Finding, flag string) []Finding {
+	var out []Finding
+	for _, f := range findings {
+		if f.Flag == flag {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func lifecycleFinding(findings []Finding, flag string) (Finding, bool) {
+	for _, f := range findings {
+		if f.Flag == flag && f.Kind == KindLifecycle {
+			return f, true
+		}
+	}
+	return Finding{}, false
+}
+
+func TestSweepLifecycleFindingsFromTestdataRegistry(t *testing.T) {
+	reg, err := LoadRegistry("testdata/flags.yaml")
+	if err != nil {
+		t.Fatalf("LoadRegistry: %v", err)
+	}
+
+	dir := t.TempDir()
+	writeFile(t, dir, "main.go", `package main
+
+func run() {
+	if isFeatureEnabled("new_checkout_flow") {
+		newCheckout()
+	}
+	if isFeatureEnabled("beta_ui") {
+		betaUI()
+	}
+	if isFeatureEnabled("feature_flag") {
+		legacy()
+	}
+}
+`)
+
+	findings, err := Sweep(dir, reg, Options{
+		Detectors: []Detector{LocalHelperDetector{FuncName: "isFeatureEnabled"}},
+	})
+	if err != nil {
+		t.Fatalf("Sweep: %v", err)
+	}
+
+	if _, ok := lifecycleFinding(findings, "new_checkout_flow"); ok {
+		t.Fatalf("expected no lifecycle finding for the active flag, got %+v", findings)
+	}
+
+	beta, ok := lifecycleFinding(findings, "beta_ui")
+	if !ok || beta.State != "deprecated" {
+		t.Fatalf("expected a deprecated lifecycle finding for beta_ui, got %+v", findings)
+	}
+	if beta.Default != "else branch" {
+		t.Fatalf("expected Reference.Default to be surfaced on the finding, got %+v", beta)
+	}
+
+	retired, ok := lifecycleFinding(findings, "feature_flag")
+	if !ok || retired.State != "retired" || retired.SuggestedRemoval == "" {
+		t.Fatalf("expected a retired finding with a suggested removal, got %+v", findings)
+	}
+}
+
+func TestSweepCrossReferencesUnregisteredAndDeadFlags(t *testing.T) {
+	reg, err := LoadRegistry("testdata/flags.yaml")
+	if err != nil {
+		t.Fatalf("LoadRegistry: %v", err)
+	}
+
+	dir := t.TempDir()
+	writeFile(t, dir, "main.go", `package main
+
+import "github.com/hbg/mcp-flag-sweeper/internal/flagregistry"
+
+var DeadFlag = flagregistry.NewFeatureFlag("dead_flag", "1.0.0", "https://example.com/issues/1", false)
+
+func run() {
+	if isFeatureEnabled("orphan_flag") {
+		doThing()
+	}
+}
+`)
+
+	findings, err := Sweep(dir, reg, Options{
+		Detectors: []Detector{LocalHelperDetector{FuncName: "isFeatureEnabled"}},
+	})
+	if err != nil {
+		t.Fatalf("Sweep: %v", err)
+	}
+
+	orphan := findingsByFlag(findings, "orphan_flag")
+	if len(orphan) != 1 || orphan[0].Kind != KindUnregistered {
+		t.Fatalf("expected an unregistered finding for orphan_flag, got %+v", orphan)
+	}
+
+	dead := findingsByFlag(findings, "dead_flag")
+	if len(dead) != 1 || dead[0].Kind != KindDeadRegistration {
+		t.Fatalf("expected a dead-registration finding for dead_flag, got %+v", dead)
+	}
+	if dead[0].VersionAdded != "1.0.0" || dead[0].RolloutIssueURL != "https://example.com/issues/1" {
+		t.Fatalf("expected the dead registration to carry its flagregistry metadata, got %+v", dead[0])
+	}
+}
+
+func TestSweepAttachesRegistrationMetadataToLifecycleFinding(t *testing.T) {
+	reg, err := LoadRegistry("testdata/flags.yaml")
+	if err != nil {
+		t.Fatalf("LoadRegistry: %v", err)
+	}
+
+	dir := t.TempDir()
+	writeFile(t, dir, "main.go", `package main
+
+import "github.com/hbg/mcp-flag-sweeper/internal/flagregistry"
+
+var BetaUI = flagregistry.NewFeatureFlag("beta_ui", "1.1.0", "https://example.com/issues/2", false)
+
+func run() {
+	if isFeatureEnabled("beta_ui") {
+		betaUI()
+	}
+}
+`)
+
+	findings, err := Sweep(dir, reg, Options{
+		Detectors: []Detector{LocalHelperDetector{FuncName: "isFeatureEnabled"}},
+	})
+	if err != nil {
+		t.Fatalf("Sweep: %v", err)
+	}
+
+	beta, ok := lifecycleFinding(findings, "beta_ui")
+	if !ok {
+		t.Fatalf("expected a lifecycle finding for beta_ui, got %+v", findings)
+	}
+	if beta.VersionAdded != "1.1.0" || beta.RolloutIssueURL != "https://example.com/issues/2" {
+		t.Fatalf("expected the lifecycle finding to carry its flagregistry metadata so cleanup PRs can reference the rollout ticket, got %+v", beta)
+	}
+}
+
+func TestSweepUsesTestdataDetectorConfig(t *testing.T) {
+	cfg, err := LoadDetectorConfig("testdata/detectors.yaml")
+	if err != nil {
+		t.Fatalf("LoadDetectorConfig: %v", err)
+	}
+	detectors, err := SelectDetectors(cfg)
+	if err != nil {
+		t.Fatalf("SelectDetectors: %v", err)
+	}
+	if len(detectors) != len(cfg.Enabled) {
+		t.Fatalf("expected one Detector per configured name, got %d for %v", len(detectors), cfg.Enabled)
+	}
+
+	reg, err := LoadRegistry("testdata/flags.yaml")
+	if err != nil {
+		t.Fatalf("LoadRegistry: %v", err)
+	}
+
+	dir := t.TempDir()
+	writeFile(t, dir, "main.go", `package main
+
+import "unleash"
+
+func run() {
+	if unleash.IsEnabled("feature_flag") {
+		legacy()
+	}
+}
+`)
+
+	findings, err := Sweep(dir, reg, Options{Detectors: detectors})
+	if err != nil {
+		t.Fatalf("Sweep: %v", err)
+	}
+	retired, ok := lifecycleFinding(findings, "feature_flag")
+	if !ok || retired.State != "retired" {
+		t.Fatalf("expected the unleash detector enabled via testdata/detectors.yaml to find feature_flag, got %+v", findings)
+	}
+}
+
+func TestSweepReportsReachedFromForContextScopedChecks(t *testing.T) {
+	reg, err := LoadRegistry("testdata/flags.yaml")
+	if err != nil {
+		t.Fatalf("LoadRegistry: %v", err)
+	}
+
+	dir := t.TempDir()
+	writeFile(t, dir, "handler.go", `package main
+
+import "net/http"
+
+func registerRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/checkout", checkoutHandler)
+}
+
+func checkoutHandler(w http.ResponseWriter, r *http.Request) {
+	doCheckout(r)
+}
+
+func doCheckout(r *http.Request) {
+	if FromContext(r.Context(), "feature_flag") {
+		legacy()
+	}
+}
+`)
+
+	findings, err := Sweep(dir, reg, Options{
+		Detectors: []Detector{FromContextDetector{}},
+	})
+	if err != nil {
+		t.Fatalf("Sweep: %v", err)
+	}
+
+	var reached []Finding
+	for _, f := range findings {
+		if f.Kind == KindReachedFrom {
+			reached = append(reached, f)
+		}
+	}
+	if len(reached) != 1 {
+		t.Fatalf("expected exactly one reached-from finding, got %+v", findings)
+	}
+	if len(reached[0].ReachedFrom) != 1 || reached[0].ReachedFrom[0] != "HTTP /checkout" {
+		t.Fatalf("expected the context check to trace back to the HTTP route, got %+v", reached[0])
+	}
+	if !sort.StringsAreSorted(reached[0].ReachedFrom) {
+		t.Fatalf("expected ReachedFrom to be sorted, got %v", reached[0].ReachedFrom)
+	}
+}